@@ -5,70 +5,114 @@ import "fmt"
 const (
 	createVerticesTable = `
 CREATE TABLE %s (
-    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    id %s,
     hash %s,
     value %s,
 	weight INT,
-	attributes JSON
+	attributes %s,
+	UNIQUE (hash)
 );`
 	createEdgesTable = `
 CREATE TABLE %s (
-	id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+	id %s,
 	source_hash %s,
 	target_hash %s,
 	weight INT,
-	attributes JSON,
-	data BLOB
+	attributes %s,
+	data %s,
+	UNIQUE (source_hash, target_hash)
 );`
 	dropVerticesTable = `DROP TABLE %s;`
 	dropEdgesTable    = `DROP TABLE %s;`
 )
 
 // DefaultConfig is a sane default configuration of the table schema. Using DefaultConfig when
-// creating a store using New makes sense for most users.
+// creating a store using New makes sense for most users. Its Dialect is left at its zero value, so
+// New detects one from the sql.DB's driver (falling back to SQLiteDialect) unless a WithDialect
+// option is given.
 var DefaultConfig = Config{
-	VerticesTable:   "vertices",
-	EdgesTable:      "edges",
-	VertexHashType:  "TEXT",
-	VertexValueType: "JSON",
+	VerticesTable: "vertices",
+	EdgesTable:    "edges",
 }
 
-// Config configures the table schema, i.e. the table names and some data types of its columns.
+// Config configures the table schema, i.e. the table names and, through Dialect, the SQL syntax
+// and column types used to create and query them.
 type Config struct {
-	VerticesTable   string
-	EdgesTable      string
-	VertexHashType  string
-	VertexValueType string
+	VerticesTable string
+	EdgesTable    string
+
+	// Dialect provides the SQL-flavor-specific column types and clauses for VerticesTable and
+	// EdgesTable. It is usually left at its zero value and populated by New, either via
+	// auto-detection or the WithDialect option.
+	Dialect Dialect
+
+	// MaterializeReverseEdges makes AddEdge, RemoveEdge and UpdateEdge write both directions of
+	// an edge transactionally, so that a reverse lookup never requires a second index. Use
+	// Store.EnsureReverseEdges to backfill the mirror rows for edges that were written before
+	// this option was enabled.
+	MaterializeReverseEdges bool
+}
+
+// Option configures a Store during construction. See WithDialect.
+type Option func(*Config)
+
+// WithDialect overrides dialect auto-detection and forces New to use the given Dialect for all
+// DDL and query generation.
+func WithDialect(dialect Dialect) Option {
+	return func(c *Config) {
+		c.Dialect = dialect
+	}
 }
 
 func createVerticesTableSQL(c Config) string {
 	return fmt.Sprintf(
 		createVerticesTable,
-		c.VerticesTable,
-		c.VertexHashType,
-		c.VertexValueType,
+		c.Dialect.QuoteIdentifier(c.VerticesTable),
+		c.Dialect.AutoIncrement,
+		c.Dialect.HashType,
+		c.Dialect.JSONType,
+		c.Dialect.JSONType,
 	)
 }
 
 func createEdgesTableSQL(c Config) string {
 	return fmt.Sprintf(
 		createEdgesTable,
-		c.EdgesTable,
-		c.VertexHashType,
-		c.VertexHashType,
+		c.Dialect.QuoteIdentifier(c.EdgesTable),
+		c.Dialect.AutoIncrement,
+		c.Dialect.HashType,
+		c.Dialect.HashType,
+		c.Dialect.JSONType,
+		c.Dialect.BytesType,
 	)
 }
 
 func dropVerticesTableSQL(c Config) string {
 	return fmt.Sprintf(
 		dropVerticesTable,
-		c.VerticesTable,
+		c.Dialect.QuoteIdentifier(c.VerticesTable),
 	)
 }
 
 func dropEdgesTableSQL(c Config) string {
 	return fmt.Sprintf(
 		dropEdgesTable,
-		c.EdgesTable,
+		c.Dialect.QuoteIdentifier(c.EdgesTable),
+	)
+}
+
+func createSourceHashIndexSQL(c Config) string {
+	return c.Dialect.CreateIndex(
+		c.Dialect.QuoteIdentifier("idx_"+c.EdgesTable+"_source_hash"),
+		c.Dialect.QuoteIdentifier(c.EdgesTable),
+		"source_hash",
+	)
+}
+
+func createTargetHashIndexSQL(c Config) string {
+	return c.Dialect.CreateIndex(
+		c.Dialect.QuoteIdentifier("idx_"+c.EdgesTable+"_target_hash"),
+		c.Dialect.QuoteIdentifier(c.EdgesTable),
+		"target_hash",
 	)
 }
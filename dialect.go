@@ -0,0 +1,258 @@
+package graphsql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Dialect captures the SQL-flavor-specific pieces of schema and query generation that differ
+// between database engines, so that the rest of the store doesn't need to hardcode any one
+// engine's syntax.
+type Dialect struct {
+	// Name identifies the dialect, e.g. "postgres", "mysql" or "sqlite".
+	Name string
+
+	// HashType is the column type used for the vertices table's hash column and the edges
+	// table's source_hash/target_hash columns.
+	HashType string
+
+	// BytesType is the column type used for binary data, i.e. the edges table's data column.
+	BytesType string
+
+	// JSONType is the column type used for the JSON-encoded value and attributes columns.
+	JSONType string
+
+	// AutoIncrement is the full column definition of the tables' auto-incrementing id primary
+	// key, e.g. "BIGSERIAL PRIMARY KEY" or "INTEGER PRIMARY KEY AUTOINCREMENT".
+	AutoIncrement string
+
+	// PlaceholderFormat is the Squirrel placeholder format to build queries with, e.g. sq.Dollar
+	// for Postgres or sq.Question for MySQL and SQLite.
+	PlaceholderFormat sq.PlaceholderFormat
+
+	// QuoteIdentifier quotes a table or column identifier in this dialect's style.
+	QuoteIdentifier func(identifier string) string
+
+	// OnConflict returns the dialect-specific clause that turns an INSERT statement into an
+	// upsert, given the columns that form the conflict target and the columns to update.
+	OnConflict func(conflictColumns, updateColumns []string) string
+
+	// IsUniqueViolation reports whether err is a unique/primary key constraint violation
+	// returned by this dialect's driver. AddVertex/AddEdge use it to map a raw driver error to
+	// graph.ErrVertexAlreadyExists/graph.ErrEdgeAlreadyExists.
+	IsUniqueViolation func(err error) bool
+
+	// JSONExtract returns the dialect-specific SQL expression that extracts a key from the JSON
+	// document stored in column, as text. The key is left as a single placeholder in the returned
+	// expression, to be bound as a query argument rather than spliced into the SQL string.
+	// AttrEq uses it to compile predicates into SQL.
+	JSONExtract func(column string) string
+
+	// CreateIndex returns the DDL statement that creates an index named indexName on
+	// table(column). Postgres and SQLite express this with IF NOT EXISTS so setupTables can run
+	// it unconditionally; MySQL's CREATE INDEX has no such clause, so its CreateIndex omits it and
+	// IsDuplicateIndex is used instead to make setupTables idempotent.
+	CreateIndex func(indexName, table, column string) string
+
+	// IsDuplicateIndex reports whether err is a "duplicate index name" error returned by this
+	// dialect's driver when an index with the given name already exists. setupTables uses it on
+	// dialects whose CreateIndex has no IF NOT EXISTS equivalent (MySQL).
+	IsDuplicateIndex func(err error) bool
+}
+
+// PostgresDialect is the built-in Dialect for PostgreSQL.
+var PostgresDialect = Dialect{
+	Name:              "postgres",
+	HashType:          "TEXT",
+	BytesType:         "BYTEA",
+	JSONType:          "JSONB",
+	AutoIncrement:     "BIGSERIAL PRIMARY KEY",
+	PlaceholderFormat: sq.Dollar,
+	QuoteIdentifier:   doubleQuoteIdentifier,
+	OnConflict:        onConflictDoUpdate,
+	IsUniqueViolation: isPostgresUniqueViolation,
+	JSONExtract:       postgresJSONExtract,
+	CreateIndex:       createIndexIfNotExists,
+}
+
+// MySQLDialect is the built-in Dialect for MySQL/MariaDB.
+var MySQLDialect = Dialect{
+	Name:              "mysql",
+	HashType:          "VARCHAR(255)",
+	BytesType:         "BLOB",
+	JSONType:          "JSON",
+	AutoIncrement:     "BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY",
+	PlaceholderFormat: sq.Question,
+	QuoteIdentifier:   backtickQuoteIdentifier,
+	OnConflict:        onDuplicateKeyUpdate,
+	IsUniqueViolation: isMySQLUniqueViolation,
+	JSONExtract:       mysqlJSONExtract,
+	CreateIndex:       mysqlCreateIndex,
+	IsDuplicateIndex:  isMySQLDuplicateIndex,
+}
+
+// SQLiteDialect is the built-in Dialect for SQLite.
+var SQLiteDialect = Dialect{
+	Name:              "sqlite",
+	HashType:          "TEXT",
+	BytesType:         "BLOB",
+	JSONType:          "JSON",
+	AutoIncrement:     "INTEGER PRIMARY KEY AUTOINCREMENT",
+	PlaceholderFormat: sq.Question,
+	QuoteIdentifier:   doubleQuoteIdentifier,
+	OnConflict:        onConflictDoUpdate,
+	IsUniqueViolation: isSQLiteUniqueViolation,
+	JSONExtract:       sqliteJSONExtract,
+	CreateIndex:       createIndexIfNotExists,
+}
+
+var dialects = map[string]Dialect{
+	"postgres": PostgresDialect,
+	"mysql":    MySQLDialect,
+	"sqlite":   SQLiteDialect,
+	"sqlite3":  SQLiteDialect,
+}
+
+// RegisterDialect makes a Dialect available under name, so it can be picked up by name from
+// DialectByName. This allows users to register their own dialects for engines this library
+// doesn't ship support for.
+func RegisterDialect(name string, dialect Dialect) {
+	dialects[name] = dialect
+}
+
+// DialectByName returns the Dialect registered under name, and false if no dialect is registered
+// under that name.
+func DialectByName(name string) (Dialect, bool) {
+	d, ok := dialects[name]
+	return d, ok
+}
+
+func doubleQuoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func backtickQuoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+func onConflictDoUpdate(conflictColumns, updateColumns []string) string {
+	sets := make([]string, len(updateColumns))
+	for i, column := range updateColumns {
+		sets[i] = fmt.Sprintf("%s = excluded.%s", column, column)
+	}
+
+	return fmt.Sprintf(
+		"ON CONFLICT (%s) DO UPDATE SET %s",
+		strings.Join(conflictColumns, ", "),
+		strings.Join(sets, ", "),
+	)
+}
+
+func onDuplicateKeyUpdate(_, updateColumns []string) string {
+	sets := make([]string, len(updateColumns))
+	for i, column := range updateColumns {
+		sets[i] = fmt.Sprintf("%s = VALUES(%s)", column, column)
+	}
+
+	return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s", strings.Join(sets, ", "))
+}
+
+// isSQLiteUniqueViolation matches on the error message instead of a driver-specific error type,
+// since this library doesn't depend on the sqlite3 driver package (which, being CGO-based, would
+// force that build requirement onto every consumer regardless of which dialect they use).
+func isSQLiteUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "SQLITE_CONSTRAINT")
+}
+
+// isPostgresUniqueViolation matches on the error message instead of a driver-specific error type,
+// since this library doesn't depend on a Postgres driver package. SQLSTATE 23505 is
+// unique_violation; see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+func isPostgresUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "SQLSTATE 23505") || strings.Contains(msg, "duplicate key value violates unique constraint")
+}
+
+// isMySQLUniqueViolation matches on the error message instead of a driver-specific error type,
+// since this library doesn't depend on a MySQL driver package. 1062 is ER_DUP_ENTRY.
+func isMySQLUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "Error 1062") || strings.Contains(msg, "Duplicate entry")
+}
+
+// postgresJSONExtract uses the ->> operator, which extracts a top-level JSON(B) key as text. The
+// key itself is left as a placeholder so callers bind it as a query argument instead of splicing
+// it into the SQL string.
+func postgresJSONExtract(column string) string {
+	return fmt.Sprintf("%s->>?", column)
+}
+
+// sqliteJSONExtract uses the ->> operator, which SQLite treats a bare key operand the same way
+// Postgres does: as shorthand for the '$.key' path. As with Postgres, the key is bound as a query
+// argument rather than spliced into the SQL string.
+func sqliteJSONExtract(column string) string {
+	return fmt.Sprintf("%s->>?", column)
+}
+
+// mysqlJSONExtract uses JSON_EXTRACT, unwrapped with JSON_UNQUOTE so the result compares equal to
+// a plain Go string instead of a quoted JSON string. The key is bound as a query argument and
+// turned into a "$.key" path with CONCAT rather than spliced into the SQL string.
+func mysqlJSONExtract(column string) string {
+	return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, CONCAT('$.', ?)))", column)
+}
+
+// createIndexIfNotExists is shared by Postgres and SQLite, both of which support an IF NOT EXISTS
+// clause on CREATE INDEX.
+func createIndexIfNotExists(indexName, table, column string) string {
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s);", indexName, table, column)
+}
+
+// mysqlCreateIndex omits IF NOT EXISTS, since MySQL's CREATE INDEX grammar has no such clause;
+// setupTables relies on IsDuplicateIndex to tolerate the index already existing.
+func mysqlCreateIndex(indexName, table, column string) string {
+	return fmt.Sprintf("CREATE INDEX %s ON %s (%s);", indexName, table, column)
+}
+
+// isMySQLDuplicateIndex matches on the error message instead of a driver-specific error type,
+// since this library doesn't depend on a MySQL driver package. 1061 is ER_DUP_KEYNAME, returned
+// when CREATE INDEX names an index that already exists.
+func isMySQLDuplicateIndex(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "Error 1061") || strings.Contains(msg, "Duplicate key name")
+}
+
+// detectDialect guesses the Dialect to use from db's driver type. Drivers that aren't recognized
+// fall back to SQLiteDialect, which matches this library's previous hardcoded behavior.
+func detectDialect(db *sql.DB) Dialect {
+	driverType := fmt.Sprintf("%T", db.Driver())
+
+	switch {
+	case strings.Contains(driverType, "postgres"), strings.Contains(driverType, "pgx"), strings.Contains(driverType, "pq."):
+		return PostgresDialect
+	case strings.Contains(driverType, "mysql"):
+		return MySQLDialect
+	case strings.Contains(driverType, "sqlite"):
+		return SQLiteDialect
+	default:
+		return SQLiteDialect
+	}
+}
@@ -0,0 +1,74 @@
+package graphsql
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestCreateVerticesTableSQLPerDialect(t *testing.T) {
+
+	assert := assert.New(t)
+
+	config := Config{VerticesTable: "vertices", EdgesTable: "edges", Dialect: PostgresDialect}
+	assert.Contains(createVerticesTableSQL(config), "BIGSERIAL PRIMARY KEY")
+	assert.Contains(createVerticesTableSQL(config), "JSONB")
+
+	config.Dialect = MySQLDialect
+	assert.Contains(createVerticesTableSQL(config), "AUTO_INCREMENT")
+	assert.Contains(createVerticesTableSQL(config), "VARCHAR(255)")
+
+	config.Dialect = SQLiteDialect
+	assert.Contains(createVerticesTableSQL(config), "AUTOINCREMENT")
+}
+
+func TestDetectDialect(t *testing.T) {
+
+	assert := assert.New(t)
+
+	db, err := sql.Open("sqlite3", "file::memory:")
+	assert.Nil(err)
+
+	dialect := detectDialect(db)
+	assert.Equal("sqlite", dialect.Name)
+}
+
+func TestWithDialectOverridesDetection(t *testing.T) {
+
+	assert := assert.New(t)
+
+	db, err := sql.Open("sqlite3", "file::memory:")
+	assert.Nil(err)
+
+	store := New[int, int](db, Config{VerticesTable: "vertices", EdgesTable: "edges"}, WithDialect(PostgresDialect))
+	assert.Equal("postgres", store.config.Dialect.Name)
+}
+
+func TestCreateIndexSQLPerDialect(t *testing.T) {
+
+	assert := assert.New(t)
+
+	config := Config{VerticesTable: "vertices", EdgesTable: "edges", Dialect: PostgresDialect}
+	assert.Contains(createSourceHashIndexSQL(config), "IF NOT EXISTS")
+
+	config.Dialect = SQLiteDialect
+	assert.Contains(createSourceHashIndexSQL(config), "IF NOT EXISTS")
+
+	config.Dialect = MySQLDialect
+	assert.NotContains(createSourceHashIndexSQL(config), "IF NOT EXISTS")
+}
+
+func TestDialectByName(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dialect, ok := DialectByName("mysql")
+	assert.True(ok)
+	assert.Equal("mysql", dialect.Name)
+
+	_, ok = DialectByName("does-not-exist")
+	assert.False(ok)
+}
@@ -0,0 +1,103 @@
+package graphsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// FindVertices returns the hashes of all vertices matching pred, pushing the filter down into the
+// database instead of loading every vertex via ListVertices and filtering it in Go.
+func (s *Store[K, T]) FindVertices(pred Predicate) ([]K, error) {
+	return s.findVertices(context.Background(), pred)
+}
+
+// FindVerticesCtx behaves like FindVertices but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) FindVerticesCtx(ctx context.Context, pred Predicate) ([]K, error) {
+	return s.findVertices(ctx, pred)
+}
+
+func (s *Store[K, T]) findVertices(ctx context.Context, pred Predicate) ([]K, error) {
+	rows, err := s.sb.
+		Select("hash").
+		From(s.config.VerticesTable).
+		Where(pred.toSqlizer(s.config.Dialect)).
+		RunWith(s.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vertices: %w", err)
+	}
+
+	it := &Iterator[K]{
+		rows: rows,
+		scan: func(rows *sql.Rows) (K, error) {
+			var hash K
+			err := rows.Scan(&hash)
+			return hash, err
+		},
+	}
+	defer it.Close()
+
+	var hashes []K
+
+	for it.Next() {
+		hash, err := it.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, it.Err()
+}
+
+// FindEdges returns all edges matching pred, pushing the filter down into the database instead of
+// loading every edge via ListEdges and filtering it in Go.
+func (s *Store[K, T]) FindEdges(pred Predicate) ([]graph.Edge[K], error) {
+	return s.findEdges(context.Background(), pred)
+}
+
+// FindEdgesCtx behaves like FindEdges but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) FindEdgesCtx(ctx context.Context, pred Predicate) ([]graph.Edge[K], error) {
+	return s.findEdges(ctx, pred)
+}
+
+func (s *Store[K, T]) findEdges(ctx context.Context, pred Predicate) ([]graph.Edge[K], error) {
+	rows, err := s.sb.
+		Select(
+			"source_hash",
+			"target_hash",
+			"weight",
+			"attributes",
+			"data",
+		).
+		From(s.config.EdgesTable).
+		Where(pred.toSqlizer(s.config.Dialect)).
+		RunWith(s.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+
+	it := &Iterator[graph.Edge[K]]{
+		rows: rows,
+		scan: scanEdgeRow[K],
+	}
+	defer it.Close()
+
+	var edges []graph.Edge[K]
+
+	for it.Next() {
+		edge, err := it.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		edges = append(edges, edge)
+	}
+
+	return edges, it.Err()
+}
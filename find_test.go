@@ -0,0 +1,68 @@
+package graphsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestFindVertices(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+
+	store.AddVertex(1, 1, graph.VertexProperties{Weight: 3, Attributes: map[string]string{"color": "red"}})
+	store.AddVertex(2, 2, graph.VertexProperties{Weight: 7, Attributes: map[string]string{"color": "blue"}})
+	store.AddVertex(3, 3, graph.VertexProperties{Weight: 9, Attributes: map[string]string{"color": "red"}})
+
+	hashes, err := store.FindVertices(AttrEq("color", "red"))
+	assert.Nil(err)
+	assert.ElementsMatch([]int{1, 3}, hashes)
+
+	hashes, err = store.FindVertices(AttrEq("color", "red").And(WeightGt(5)))
+	assert.Nil(err)
+	assert.ElementsMatch([]int{3}, hashes)
+}
+
+func TestAttrEqDoesNotInjectSQL(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+
+	store.AddVertex(1, 1, graph.VertexProperties{Attributes: map[string]string{"color": "red"}})
+
+	hashes, err := store.FindVertices(AttrEq("nonexistent' OR '1'='1' --", "x"))
+	assert.Nil(err)
+	assert.Empty(hashes)
+}
+
+func TestFindEdges(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+	store.AddVertex(3, 3, graph.VertexProperties{})
+
+	store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2, Properties: graph.EdgeProperties{Weight: 2}})
+	store.AddEdge(2, 3, graph.Edge[int]{Source: 2, Target: 3, Properties: graph.EdgeProperties{Weight: 8}})
+
+	edges, err := store.FindEdges(WeightGt(5))
+	assert.Nil(err)
+	assert.Equal(1, len(edges))
+	assert.Equal(2, edges[0].Source)
+	assert.Equal(3, edges[0].Target)
+
+	edges, err = store.FindEdges(WeightLt(5).Or(WeightEq(8)))
+	assert.Nil(err)
+	assert.Equal(2, len(edges))
+}
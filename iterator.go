@@ -0,0 +1,194 @@
+package graphsql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Iterator streams query results one row at a time instead of loading them all into memory, and
+// closes the underlying *sql.Rows once Close is called or the rows are exhausted.
+type Iterator[V any] struct {
+	rows *sql.Rows
+	scan func(*sql.Rows) (V, error)
+}
+
+// Next prepares the next row for reading via Value. It returns false once there are no more rows
+// or an error occurred, in which case Err returns that error.
+func (it *Iterator[V]) Next() bool {
+	return it.rows.Next()
+}
+
+// Value scans and returns the current row.
+func (it *Iterator[V]) Value() (V, error) {
+	return it.scan(it.rows)
+}
+
+// Err returns the error, if any, that stopped Next from returning further rows.
+func (it *Iterator[V]) Err() error {
+	return it.rows.Err()
+}
+
+// Close closes the underlying *sql.Rows, releasing the connection back to the pool. It must be
+// called once the iterator is no longer needed.
+func (it *Iterator[V]) Close() error {
+	return it.rows.Close()
+}
+
+// VerticesIter returns an Iterator over all vertex hashes, fetching rows from the database one at
+// a time rather than loading them all into a slice upfront.
+func (s *Store[K, T]) VerticesIter(ctx context.Context) (*Iterator[K], error) {
+	rows, err := s.sb.
+		Select("hash").
+		From(s.config.VerticesTable).
+		RunWith(s.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vertices: %w", err)
+	}
+
+	return &Iterator[K]{
+		rows: rows,
+		scan: func(rows *sql.Rows) (K, error) {
+			var hash K
+			err := rows.Scan(&hash)
+			return hash, err
+		},
+	}, nil
+}
+
+// EdgesIter returns an Iterator over all edges, fetching rows from the database one at a time
+// rather than loading them all into a slice upfront.
+func (s *Store[K, T]) EdgesIter(ctx context.Context) (*Iterator[graph.Edge[K]], error) {
+	rows, err := s.sb.
+		Select(
+			"source_hash",
+			"target_hash",
+			"weight",
+			"attributes",
+			"data",
+		).
+		From(s.config.EdgesTable).
+		RunWith(s.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+
+	return &Iterator[graph.Edge[K]]{
+		rows: rows,
+		scan: scanEdgeRow[K],
+	}, nil
+}
+
+func scanEdgeRow[K comparable](rows *sql.Rows) (graph.Edge[K], error) {
+	var (
+		edge            graph.Edge[K]
+		attributesBytes []byte
+	)
+
+	if err := rows.Scan(
+		&edge.Source,
+		&edge.Target,
+		&edge.Properties.Weight,
+		&attributesBytes,
+		&edge.Properties.Data,
+	); err != nil {
+		return edge, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	if err := json.Unmarshal(attributesBytes, &edge.Properties.Attributes); err != nil {
+		return edge, fmt.Errorf("failed to unmarshal attributes: %w", err)
+	}
+
+	return edge, nil
+}
+
+// ListVerticesPage returns at most limit vertex hashes, skipping the first offset of them. Use it
+// together with VertexCount to page through large graphs instead of loading every vertex via
+// ListVertices.
+func (s *Store[K, T]) ListVerticesPage(offset, limit int) ([]K, error) {
+	return s.listVerticesPage(context.Background(), offset, limit)
+}
+
+// ListVerticesPageCtx behaves like ListVerticesPage but threads ctx through the underlying SQL
+// execution.
+func (s *Store[K, T]) ListVerticesPageCtx(ctx context.Context, offset, limit int) ([]K, error) {
+	return s.listVerticesPage(ctx, offset, limit)
+}
+
+func (s *Store[K, T]) listVerticesPage(ctx context.Context, offset, limit int) ([]K, error) {
+	rows, err := s.sb.
+		Select("hash").
+		From(s.config.VerticesTable).
+		Offset(uint64(offset)).
+		Limit(uint64(limit)).
+		RunWith(s.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vertices: %w", err)
+	}
+	defer rows.Close()
+
+	var hashes []K
+
+	for rows.Next() {
+		var hash K
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, rows.Err()
+}
+
+// ListEdgesPage returns at most limit edges, skipping the first offset of them. Use it together
+// with EdgeCount to page through large graphs instead of loading every edge via ListEdges.
+func (s *Store[K, T]) ListEdgesPage(offset, limit int) ([]graph.Edge[K], error) {
+	return s.listEdgesPage(context.Background(), offset, limit)
+}
+
+// ListEdgesPageCtx behaves like ListEdgesPage but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) ListEdgesPageCtx(ctx context.Context, offset, limit int) ([]graph.Edge[K], error) {
+	return s.listEdgesPage(ctx, offset, limit)
+}
+
+func (s *Store[K, T]) listEdgesPage(ctx context.Context, offset, limit int) ([]graph.Edge[K], error) {
+	rows, err := s.sb.
+		Select(
+			"source_hash",
+			"target_hash",
+			"weight",
+			"attributes",
+			"data",
+		).
+		From(s.config.EdgesTable).
+		Offset(uint64(offset)).
+		Limit(uint64(limit)).
+		RunWith(s.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []graph.Edge[K]
+
+	for rows.Next() {
+		edge, err := scanEdgeRow[K](rows)
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, edge)
+	}
+
+	return edges, rows.Err()
+}
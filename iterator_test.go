@@ -0,0 +1,111 @@
+package graphsql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestVerticesIter(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+	store.AddVertex(3, 3, graph.VertexProperties{})
+
+	it, err := store.VerticesIter(context.Background())
+	assert.Nil(err)
+
+	var hashes []int
+	for it.Next() {
+		hash, err := it.Value()
+		assert.Nil(err)
+		hashes = append(hashes, hash)
+	}
+	assert.Nil(it.Err())
+	assert.Nil(it.Close())
+
+	assert.ElementsMatch([]int{1, 2, 3}, hashes)
+}
+
+func TestEdgesIter(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+
+	store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2})
+
+	it, err := store.EdgesIter(context.Background())
+	assert.Nil(err)
+
+	var edges []graph.Edge[int]
+	for it.Next() {
+		edge, err := it.Value()
+		assert.Nil(err)
+		edges = append(edges, edge)
+	}
+	assert.Nil(it.Err())
+	assert.Nil(it.Close())
+
+	assert.Equal(1, len(edges))
+}
+
+func TestListVerticesPage(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	for i := 1; i <= 5; i++ {
+		store.AddVertex(i, i, graph.VertexProperties{})
+	}
+
+	page, err := store.ListVerticesPage(0, 2)
+	assert.Nil(err)
+	assert.Equal(2, len(page))
+
+	page, err = store.ListVerticesPage(4, 2)
+	assert.Nil(err)
+	assert.Equal(1, len(page))
+}
+
+func TestListEdgesPage(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+	store.AddVertex(3, 3, graph.VertexProperties{})
+
+	store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2})
+	store.AddEdge(2, 3, graph.Edge[int]{Source: 2, Target: 3})
+	store.AddEdge(1, 3, graph.Edge[int]{Source: 1, Target: 3})
+
+	page, err := store.ListEdgesPage(0, 2)
+	assert.Nil(err)
+	assert.Equal(2, len(page))
+
+	page, err = store.ListEdgesPage(2, 2)
+	assert.Nil(err)
+	assert.Equal(1, len(page))
+}
@@ -0,0 +1,199 @@
+package graphsql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Neighbors returns the hashes of all vertices adjacent to hash as well as the edges connecting
+// them, fetched in a single query that joins the edges table to the vertices table on
+// source_hash/target_hash. This avoids scanning ListEdges in Go for the common case of looking up
+// a vertex's neighborhood.
+func (s *Store[K, T]) Neighbors(hash K) ([]K, []graph.Edge[K], error) {
+	return s.neighbors(context.Background(), s.db, hash)
+}
+
+// NeighborsCtx behaves like Neighbors but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) NeighborsCtx(ctx context.Context, hash K) ([]K, []graph.Edge[K], error) {
+	return s.neighbors(ctx, s.db, hash)
+}
+
+func (s *Store[K, T]) neighbors(ctx context.Context, exec sqlExecutor, hash K) ([]K, []graph.Edge[K], error) {
+	rows, err := s.sb.
+		Select(
+			"e.source_hash",
+			"e.target_hash",
+			"e.weight",
+			"e.attributes",
+			"e.data",
+		).
+		From(s.config.EdgesTable+" AS e").
+		Join(fmt.Sprintf("%s AS src ON src.hash = e.source_hash", s.config.VerticesTable)).
+		Join(fmt.Sprintf("%s AS tgt ON tgt.hash = e.target_hash", s.config.VerticesTable)).
+		Where(sq.Or{
+			sq.Eq{"e.source_hash": hash},
+			sq.Eq{"e.target_hash": hash},
+		}).
+		RunWith(exec).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query neighbors: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		hashes []K
+		edges  []graph.Edge[K]
+	)
+
+	for rows.Next() {
+		var (
+			edge            graph.Edge[K]
+			attributesBytes []byte
+		)
+
+		if err := rows.Scan(
+			&edge.Source,
+			&edge.Target,
+			&edge.Properties.Weight,
+			&attributesBytes,
+			&edge.Properties.Data,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if err := json.Unmarshal(attributesBytes, &edge.Properties.Attributes); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal attributes: %w", err)
+		}
+
+		edges = append(edges, edge)
+
+		neighbor := edge.Target
+		if edge.Target == hash {
+			neighbor = edge.Source
+		}
+		hashes = append(hashes, neighbor)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return hashes, edges, nil
+}
+
+// OutEdges returns all edges originating from hash.
+func (s *Store[K, T]) OutEdges(hash K) ([]graph.Edge[K], error) {
+	return s.edgesWhere(context.Background(), s.db, sq.Eq{"source_hash": hash})
+}
+
+// OutEdgesCtx behaves like OutEdges but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) OutEdgesCtx(ctx context.Context, hash K) ([]graph.Edge[K], error) {
+	return s.edgesWhere(ctx, s.db, sq.Eq{"source_hash": hash})
+}
+
+// InEdges returns all edges pointing to hash.
+func (s *Store[K, T]) InEdges(hash K) ([]graph.Edge[K], error) {
+	return s.edgesWhere(context.Background(), s.db, sq.Eq{"target_hash": hash})
+}
+
+// InEdgesCtx behaves like InEdges but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) InEdgesCtx(ctx context.Context, hash K) ([]graph.Edge[K], error) {
+	return s.edgesWhere(ctx, s.db, sq.Eq{"target_hash": hash})
+}
+
+func (s *Store[K, T]) edgesWhere(ctx context.Context, exec sqlExecutor, pred sq.Eq) ([]graph.Edge[K], error) {
+	rows, err := s.sb.
+		Select(
+			"source_hash",
+			"target_hash",
+			"weight",
+			"attributes",
+			"data",
+		).
+		From(s.config.EdgesTable).
+		Where(pred).
+		RunWith(exec).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []graph.Edge[K]
+
+	for rows.Next() {
+		var (
+			edge            graph.Edge[K]
+			attributesBytes []byte
+		)
+
+		if err := rows.Scan(
+			&edge.Source,
+			&edge.Target,
+			&edge.Properties.Weight,
+			&attributesBytes,
+			&edge.Properties.Data,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if err := json.Unmarshal(attributesBytes, &edge.Properties.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal attributes: %w", err)
+		}
+
+		edges = append(edges, edge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return edges, nil
+}
+
+// Degree returns the in-degree and out-degree of the vertex with the given hash, i.e. the number
+// of edges pointing to it and the number of edges originating from it, respectively.
+func (s *Store[K, T]) Degree(hash K) (in, out int, err error) {
+	return s.degree(context.Background(), s.db, hash)
+}
+
+// DegreeCtx behaves like Degree but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) DegreeCtx(ctx context.Context, hash K) (in, out int, err error) {
+	return s.degree(ctx, s.db, hash)
+}
+
+func (s *Store[K, T]) degree(ctx context.Context, exec sqlExecutor, hash K) (in, out int, err error) {
+	err = s.sb.
+		Select("count(source_hash)").
+		From(s.config.EdgesTable).
+		Where(sq.Eq{"target_hash": hash}).
+		RunWith(exec).
+		QueryRowContext(ctx).
+		Scan(&in)
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count in-edges: %w", err)
+	}
+
+	err = s.sb.
+		Select("count(source_hash)").
+		From(s.config.EdgesTable).
+		Where(sq.Eq{"source_hash": hash}).
+		RunWith(exec).
+		QueryRowContext(ctx).
+		Scan(&out)
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count out-edges: %w", err)
+	}
+
+	return in, out, nil
+}
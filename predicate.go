@@ -0,0 +1,82 @@
+package graphsql
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Predicate is a filter condition that FindVertices and FindEdges compile into a SQL WHERE
+// clause, instead of loading every row and filtering it in Go. Build one with AttrEq, WeightEq,
+// WeightGt, WeightLt or DataEq, and combine multiple with And or Or.
+type Predicate struct {
+	sqlizer func(d Dialect) sq.Sqlizer
+}
+
+func (p Predicate) toSqlizer(d Dialect) sq.Sqlizer {
+	return p.sqlizer(d)
+}
+
+// And returns a Predicate that matches only rows matching both p and other.
+func (p Predicate) And(other Predicate) Predicate {
+	return Predicate{
+		sqlizer: func(d Dialect) sq.Sqlizer {
+			return sq.And{p.toSqlizer(d), other.toSqlizer(d)}
+		},
+	}
+}
+
+// Or returns a Predicate that matches rows matching either p or other.
+func (p Predicate) Or(other Predicate) Predicate {
+	return Predicate{
+		sqlizer: func(d Dialect) sq.Sqlizer {
+			return sq.Or{p.toSqlizer(d), other.toSqlizer(d)}
+		},
+	}
+}
+
+// AttrEq returns a Predicate matching rows whose attributes JSON has key set to value, using the
+// dialect's JSONExtract expression. key and value are both bound as query arguments, never
+// spliced into the generated SQL.
+func AttrEq(key, value string) Predicate {
+	return Predicate{
+		sqlizer: func(d Dialect) sq.Sqlizer {
+			return sq.Expr(d.JSONExtract("attributes")+" = ?", key, value)
+		},
+	}
+}
+
+// WeightEq returns a Predicate matching rows whose weight equals weight.
+func WeightEq(weight int) Predicate {
+	return Predicate{
+		sqlizer: func(Dialect) sq.Sqlizer {
+			return sq.Eq{"weight": weight}
+		},
+	}
+}
+
+// WeightGt returns a Predicate matching rows whose weight is greater than weight.
+func WeightGt(weight int) Predicate {
+	return Predicate{
+		sqlizer: func(Dialect) sq.Sqlizer {
+			return sq.Gt{"weight": weight}
+		},
+	}
+}
+
+// WeightLt returns a Predicate matching rows whose weight is less than weight.
+func WeightLt(weight int) Predicate {
+	return Predicate{
+		sqlizer: func(Dialect) sq.Sqlizer {
+			return sq.Lt{"weight": weight}
+		},
+	}
+}
+
+// DataEq returns a Predicate matching edges whose data column equals data. It is only meaningful
+// for FindEdges, since the vertices table has no data column.
+func DataEq(data []byte) Predicate {
+	return Predicate{
+		sqlizer: func(Dialect) sq.Sqlizer {
+			return sq.Eq{"data": data}
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package graphsql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// edgeKey identifies an edge by its source and target hash, ignoring weight/attributes/data.
+type edgeKey[K comparable] struct {
+	source K
+	target K
+}
+
+// EnsureReverseEdges scans the edges table and, for every (source, target) edge without a
+// matching (target, source) edge, inserts the mirror row with the same weight, attributes and
+// data. It is the maintenance counterpart to config.MaterializeReverseEdges for edges that were
+// written before that option was enabled.
+func (s *Store[K, T]) EnsureReverseEdges(ctx context.Context) error {
+	edges, err := s.listEdges(ctx, s.db)
+	if err != nil {
+		return fmt.Errorf("failed to list edges: %w", err)
+	}
+
+	existing := make(map[edgeKey[K]]struct{}, len(edges))
+	for _, edge := range edges {
+		existing[edgeKey[K]{edge.Source, edge.Target}] = struct{}{}
+	}
+
+	var missing []graph.Edge[K]
+
+	for _, edge := range edges {
+		if edge.Source == edge.Target {
+			continue
+		}
+
+		reverseKey := edgeKey[K]{edge.Target, edge.Source}
+		if _, ok := existing[reverseKey]; ok {
+			continue
+		}
+
+		reverse := edge
+		reverse.Source, reverse.Target = edge.Target, edge.Source
+		missing = append(missing, reverse)
+
+		// Mark the mirror as existing so duplicate source edges don't queue it twice.
+		existing[reverseKey] = struct{}{}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return s.WithTx(ctx, func(tx *Tx[K, T]) error {
+		for _, reverse := range missing {
+			if err := tx.store.upsertEdge(tx.ctx, tx.tx, reverse.Source, reverse.Target, reverse); err != nil {
+				return fmt.Errorf("failed to materialize reverse edge: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,160 @@
+package graphsql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dominikbraun/graph"
+)
+
+func createStoreWithReverseEdges() (*Store[int, int], error) {
+	store, err := createStore[int, int]()
+	if err != nil {
+		return nil, err
+	}
+	store.config.MaterializeReverseEdges = true
+	return store, nil
+}
+
+func TestAddEdgeMaterializesReverse(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStoreWithReverseEdges()
+	assert.Nil(err)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+
+	err = store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2, Properties: graph.EdgeProperties{Weight: 3}})
+	assert.Nil(err)
+
+	reverse, err := store.Edge(2, 1)
+	assert.Nil(err)
+	assert.Equal(3, reverse.Properties.Weight)
+
+	edgeCount, err := store.EdgeCount()
+	assert.Nil(err)
+	assert.Equal(2, edgeCount)
+}
+
+func TestRemoveEdgeRemovesReverse(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStoreWithReverseEdges()
+	assert.Nil(err)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+
+	store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2})
+
+	err = store.RemoveEdge(1, 2)
+	assert.Nil(err)
+
+	_, err = store.Edge(2, 1)
+	assert.Equal(graph.ErrEdgeNotFound, err)
+}
+
+func TestUpdateEdgeUpdatesReverse(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStoreWithReverseEdges()
+	assert.Nil(err)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+
+	store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2})
+
+	err = store.UpdateEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2, Properties: graph.EdgeProperties{Weight: 7}})
+	assert.Nil(err)
+
+	reverse, err := store.Edge(2, 1)
+	assert.Nil(err)
+	assert.Equal(7, reverse.Properties.Weight)
+}
+
+func TestUpdateEdgeCreatesMissingReverse(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+
+	// Added before MaterializeReverseEdges was turned on, so no mirror edge exists yet.
+	store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2})
+	store.config.MaterializeReverseEdges = true
+
+	err = store.UpdateEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2, Properties: graph.EdgeProperties{Weight: 7}})
+	assert.Nil(err)
+
+	reverse, err := store.Edge(2, 1)
+	assert.Nil(err)
+	assert.Equal(7, reverse.Properties.Weight)
+
+	edgeCount, err := store.EdgeCount()
+	assert.Nil(err)
+	assert.Equal(2, edgeCount)
+}
+
+func TestTxAddEdgeMaterializesReverse(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStoreWithReverseEdges()
+	assert.Nil(err)
+
+	err = store.WithTx(context.Background(), func(tx *Tx[int, int]) error {
+		if err := tx.AddVertex(1, 1, graph.VertexProperties{}); err != nil {
+			return err
+		}
+		if err := tx.AddVertex(2, 2, graph.VertexProperties{}); err != nil {
+			return err
+		}
+		return tx.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2, Properties: graph.EdgeProperties{Weight: 3}})
+	})
+	assert.Nil(err)
+
+	reverse, err := store.Edge(2, 1)
+	assert.Nil(err)
+	assert.Equal(3, reverse.Properties.Weight)
+
+	edgeCount, err := store.EdgeCount()
+	assert.Nil(err)
+	assert.Equal(2, edgeCount)
+}
+
+func TestEnsureReverseEdges(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+	store.AddVertex(3, 3, graph.VertexProperties{})
+
+	store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2, Properties: graph.EdgeProperties{Weight: 5}})
+	store.AddEdge(2, 3, graph.Edge[int]{Source: 2, Target: 3})
+	store.AddEdge(3, 2, graph.Edge[int]{Source: 3, Target: 2})
+
+	err = store.EnsureReverseEdges(context.Background())
+	assert.Nil(err)
+
+	reverse, err := store.Edge(2, 1)
+	assert.Nil(err)
+	assert.Equal(5, reverse.Properties.Weight)
+
+	edgeCount, err := store.EdgeCount()
+	assert.Nil(err)
+	assert.Equal(4, edgeCount)
+}
@@ -1,6 +1,7 @@
 package graphsql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -11,45 +12,106 @@ import (
 	sq "github.com/Masterminds/squirrel"
 )
 
+// sqlExecutor is satisfied by both *sql.DB and *sql.Tx. Store's write and read logic is built on
+// top of this interface so that it can run either directly against the database or within a
+// transaction started by WithTx.
+type sqlExecutor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // Store is a graph.Store implementation that uses an SQL database to store and retrieve graphs.
 type Store[K comparable, T any] struct {
 	db     *sql.DB
 	config Config
+	sb     sq.StatementBuilderType
 }
 
 // New creates a new SQL store that can be passed to graph.NewWithStore. It expects a database
 // connection directly to the actual database schema in the form of a sql.DB instance.
-func New[K comparable, T any](db *sql.DB, config Config) *Store[K, T] {
+//
+// If config.Dialect is left at its zero value, New tries to detect the right Dialect from db's
+// driver, falling back to SQLiteDialect if it can't. Pass WithDialect to override this, e.g. when
+// the driver can't be detected or a custom Dialect should be used.
+func New[K comparable, T any](db *sql.DB, config Config, opts ...Option) *Store[K, T] {
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.Dialect.Name == "" {
+		config.Dialect = detectDialect(db)
+	}
+
 	return &Store[K, T]{
 		db:     db,
 		config: config,
+		sb:     sq.StatementBuilder.PlaceholderFormat(config.Dialect.PlaceholderFormat),
 	}
 }
 
 // SetupTables creates all required tables inside the configured database. The schema is documented
 // in this library's README file.
 func (s *Store[K, T]) SetupTables() error {
-	_, err := s.db.Exec(createVerticesTableSQL(s.config))
+	return s.setupTables(context.Background())
+}
+
+// SetupTablesCtx behaves like SetupTables but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) SetupTablesCtx(ctx context.Context) error {
+	return s.setupTables(ctx)
+}
+
+func (s *Store[K, T]) setupTables(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, createVerticesTableSQL(s.config))
 	if err != nil {
 		return fmt.Errorf("failed to set up %s table: %w", s.config.VerticesTable, err)
 	}
 
-	_, err = s.db.Exec(createEdgesTableSQL(s.config))
+	_, err = s.db.ExecContext(ctx, createEdgesTableSQL(s.config))
 	if err != nil {
 		return fmt.Errorf("failed to set up %s table: %w", s.config.EdgesTable, err)
 	}
 
+	_, err = s.db.ExecContext(ctx, createSourceHashIndexSQL(s.config))
+	if err != nil && !s.isDuplicateIndex(err) {
+		return fmt.Errorf("failed to set up source_hash index on %s: %w", s.config.EdgesTable, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, createTargetHashIndexSQL(s.config))
+	if err != nil && !s.isDuplicateIndex(err) {
+		return fmt.Errorf("failed to set up target_hash index on %s: %w", s.config.EdgesTable, err)
+	}
+
 	return nil
 }
 
+// isDuplicateIndex reports whether err is the dialect's "duplicate index name" error, so
+// setupTables can run CreateIndex unconditionally even on dialects (MySQL) that don't support
+// IF NOT EXISTS on CREATE INDEX.
+func (s *Store[K, T]) isDuplicateIndex(err error) bool {
+	return s.config.Dialect.IsDuplicateIndex != nil && s.config.Dialect.IsDuplicateIndex(err)
+}
+
 // DestroyTables drops all tables and thus removes all data from the database.
 func (s *Store[K, T]) DestroyTables() error {
-	_, err := s.db.Exec(dropEdgesTableSQL(s.config))
+	return s.destroyTables(context.Background())
+}
+
+// DestroyTablesCtx behaves like DestroyTables but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) DestroyTablesCtx(ctx context.Context) error {
+	return s.destroyTables(ctx)
+}
+
+func (s *Store[K, T]) destroyTables(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, dropEdgesTableSQL(s.config))
 	if err != nil {
 		return fmt.Errorf("failed to set up %s table: %w", s.config.EdgesTable, err)
 	}
 
-	_, err = s.db.Exec(dropVerticesTableSQL(s.config))
+	_, err = s.db.ExecContext(ctx, dropVerticesTableSQL(s.config))
 	if err != nil {
 		return fmt.Errorf("failed to set up %s table: %w", s.config.VerticesTable, err)
 	}
@@ -59,6 +121,15 @@ func (s *Store[K, T]) DestroyTables() error {
 
 // AddVertex implements graph.Store.AddVertex.
 func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	return s.addVertex(context.Background(), s.db, hash, value, properties)
+}
+
+// AddVertexCtx behaves like AddVertex but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) AddVertexCtx(ctx context.Context, hash K, value T, properties graph.VertexProperties) error {
+	return s.addVertex(ctx, s.db, hash, value, properties)
+}
+
+func (s *Store[K, T]) addVertex(ctx context.Context, exec sqlExecutor, hash K, value T, properties graph.VertexProperties) error {
 	valueBytes, err := json.Marshal(value)
 	if err != nil {
 		return err
@@ -69,31 +140,53 @@ func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperti
 		return err
 	}
 
-	_, err = sq.
+	_, err = s.sb.
 		Insert(s.config.VerticesTable).
 		Columns("hash", "value", "weight", "attributes").
 		Values(hash, valueBytes, properties.Weight, attributeBytes).
-		RunWith(s.db).
-		Exec()
+		RunWith(exec).
+		ExecContext(ctx)
+
+	if s.config.Dialect.IsUniqueViolation != nil && s.config.Dialect.IsUniqueViolation(err) {
+		return graph.ErrVertexAlreadyExists
+	}
 
 	return err
 }
 
 // RemoveVertex implements graph.Store.RemoveVertex.
 func (s *Store[K, T]) RemoveVertex(hash K) error {
-	_, err := sq.
+	return s.removeVertex(context.Background(), s.db, hash)
+}
+
+// RemoveVertexCtx behaves like RemoveVertex but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) RemoveVertexCtx(ctx context.Context, hash K) error {
+	return s.removeVertex(ctx, s.db, hash)
+}
+
+func (s *Store[K, T]) removeVertex(ctx context.Context, exec sqlExecutor, hash K) error {
+	_, err := s.sb.
 		Delete(s.config.VerticesTable).
 		Where(sq.Eq{
 			"hash": hash,
 		}).
-		RunWith(s.db).
-		Exec()
+		RunWith(exec).
+		ExecContext(ctx)
 
 	return err
 }
 
 // Vertex implements graph.Store.Vertex.
 func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	return s.vertex(context.Background(), s.db, hash)
+}
+
+// VertexCtx behaves like Vertex but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) VertexCtx(ctx context.Context, hash K) (T, graph.VertexProperties, error) {
+	return s.vertex(ctx, s.db, hash)
+}
+
+func (s *Store[K, T]) vertex(ctx context.Context, exec sqlExecutor, hash K) (T, graph.VertexProperties, error) {
 	var (
 		valueBytes      []byte
 		attributesBytes []byte
@@ -101,14 +194,18 @@ func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
 		properties      graph.VertexProperties
 	)
 
-	err := sq.
+	err := s.sb.
 		Select("value", "weight", "attributes").
 		From(s.config.VerticesTable).
 		Where(sq.Eq{"hash": hash}).
-		RunWith(s.db).
-		QueryRow().
+		RunWith(exec).
+		QueryRowContext(ctx).
 		Scan(&valueBytes, &properties.Weight, &attributesBytes)
 
+	if errors.Is(err, sql.ErrNoRows) {
+		return value, properties, graph.ErrVertexNotFound
+	}
+
 	if err != nil {
 		return value, properties, fmt.Errorf("failed to query vertex: %w", err)
 	}
@@ -126,51 +223,117 @@ func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
 
 // ListVertices implements graph.Store.ListVertices.
 func (s *Store[K, T]) ListVertices() ([]K, error) {
-	rows, err := sq.
+	return s.listVertices(context.Background(), s.db)
+}
+
+// ListVerticesCtx behaves like ListVertices but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) ListVerticesCtx(ctx context.Context) ([]K, error) {
+	return s.listVertices(ctx, s.db)
+}
+
+func (s *Store[K, T]) listVertices(ctx context.Context, exec sqlExecutor) ([]K, error) {
+	rows, err := s.sb.
 		Select("hash").
 		From(s.config.VerticesTable).
-		RunWith(s.db).
-		Query()
+		RunWith(exec).
+		QueryContext(ctx)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to query vertices: %w", err)
 	}
 
+	it := &Iterator[K]{
+		rows: rows,
+		scan: func(rows *sql.Rows) (K, error) {
+			var hash K
+			err := rows.Scan(&hash)
+			return hash, err
+		},
+	}
+	defer it.Close()
+
 	var hashes []K
 
-	for rows.Next() {
-		var hash K
-		if err := rows.Scan(&hash); err != nil {
+	for it.Next() {
+		hash, err := it.Value()
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		hashes = append(hashes, hash)
 	}
 
-	return hashes, nil
+	return hashes, it.Err()
 }
 
 // VertexCount implements graph.Store.VertexCount.
 func (s *Store[K, T]) VertexCount() (int, error) {
+	return s.vertexCount(context.Background(), s.db)
+}
+
+// VertexCountCtx behaves like VertexCount but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) VertexCountCtx(ctx context.Context) (int, error) {
+	return s.vertexCount(ctx, s.db)
+}
+
+func (s *Store[K, T]) vertexCount(ctx context.Context, exec sqlExecutor) (int, error) {
 	var count int
 
-	err := sq.
+	err := s.sb.
 		Select("count(hash)").
 		From(s.config.VerticesTable).
-		RunWith(s.db).
-		QueryRow().
+		RunWith(exec).
+		QueryRowContext(ctx).
 		Scan(&count)
 
 	return count, err
 }
 
-// AddEdge implements graph.Store.AddEdge.
+// AddEdge implements graph.Store.AddEdge. If config.MaterializeReverseEdges is enabled, it also
+// writes the mirrored (targetHash, sourceHash) edge in the same transaction.
 func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return s.addEdgeWithReverse(context.Background(), sourceHash, targetHash, edge)
+}
+
+// AddEdgeCtx behaves like AddEdge but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) AddEdgeCtx(ctx context.Context, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return s.addEdgeWithReverse(ctx, sourceHash, targetHash, edge)
+}
+
+func (s *Store[K, T]) addEdgeWithReverse(ctx context.Context, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	if !s.config.MaterializeReverseEdges || sourceHash == targetHash {
+		return s.addEdge(ctx, s.db, sourceHash, targetHash, edge)
+	}
+
+	return s.WithTx(ctx, func(tx *Tx[K, T]) error {
+		return tx.store.addEdgeReverseAware(tx.ctx, tx.tx, sourceHash, targetHash, edge)
+	})
+}
+
+// addEdgeReverseAware writes the (sourceHash, targetHash) edge and, if config.MaterializeReverseEdges
+// is enabled, its mirror, both against exec. Tx.AddEdge calls this directly so that grouping writes
+// through WithTx still honors MaterializeReverseEdges the same way Store.AddEdge does.
+func (s *Store[K, T]) addEdgeReverseAware(ctx context.Context, exec sqlExecutor, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	if err := s.addEdge(ctx, exec, sourceHash, targetHash, edge); err != nil {
+		return err
+	}
+
+	if !s.config.MaterializeReverseEdges || sourceHash == targetHash {
+		return nil
+	}
+
+	reverse := edge
+	reverse.Source, reverse.Target = targetHash, sourceHash
+
+	return s.addEdge(ctx, exec, targetHash, sourceHash, reverse)
+}
+
+func (s *Store[K, T]) addEdge(ctx context.Context, exec sqlExecutor, sourceHash, targetHash K, edge graph.Edge[K]) error {
 	attributesBytes, err := json.Marshal(edge.Properties.Attributes)
 	if err != nil {
 		return err
 	}
 
-	_, err = sq.
+	_, err = s.sb.
 		Insert(s.config.EdgesTable).
 		Columns(
 			"source_hash",
@@ -186,28 +349,77 @@ func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) erro
 			attributesBytes,
 			edge.Properties.Data,
 		).
-		RunWith(s.db).
-		Exec()
+		RunWith(exec).
+		ExecContext(ctx)
+
+	if s.config.Dialect.IsUniqueViolation != nil && s.config.Dialect.IsUniqueViolation(err) {
+		return graph.ErrEdgeAlreadyExists
+	}
 
 	return err
 }
 
-// RemoveEdge implements graph.Store.RemoveEdge.
+// RemoveEdge implements graph.Store.RemoveEdge. If config.MaterializeReverseEdges is enabled, it
+// also removes the mirrored (targetHash, sourceHash) edge in the same transaction.
 func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
-	_, err := sq.
+	return s.removeEdgeWithReverse(context.Background(), sourceHash, targetHash)
+}
+
+// RemoveEdgeCtx behaves like RemoveEdge but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) RemoveEdgeCtx(ctx context.Context, sourceHash, targetHash K) error {
+	return s.removeEdgeWithReverse(ctx, sourceHash, targetHash)
+}
+
+func (s *Store[K, T]) removeEdgeWithReverse(ctx context.Context, sourceHash, targetHash K) error {
+	if !s.config.MaterializeReverseEdges || sourceHash == targetHash {
+		return s.removeEdge(ctx, s.db, sourceHash, targetHash)
+	}
+
+	return s.WithTx(ctx, func(tx *Tx[K, T]) error {
+		return tx.store.removeEdgeReverseAware(tx.ctx, tx.tx, sourceHash, targetHash)
+	})
+}
+
+// removeEdgeReverseAware removes the (sourceHash, targetHash) edge and, if
+// config.MaterializeReverseEdges is enabled, its mirror, both against exec. Tx.RemoveEdge calls
+// this directly so that grouping writes through WithTx still honors MaterializeReverseEdges the
+// same way Store.RemoveEdge does.
+func (s *Store[K, T]) removeEdgeReverseAware(ctx context.Context, exec sqlExecutor, sourceHash, targetHash K) error {
+	if err := s.removeEdge(ctx, exec, sourceHash, targetHash); err != nil {
+		return err
+	}
+
+	if !s.config.MaterializeReverseEdges || sourceHash == targetHash {
+		return nil
+	}
+
+	return s.removeEdge(ctx, exec, targetHash, sourceHash)
+}
+
+func (s *Store[K, T]) removeEdge(ctx context.Context, exec sqlExecutor, sourceHash, targetHash K) error {
+	_, err := s.sb.
 		Delete(s.config.EdgesTable).
 		Where(sq.Eq{
 			"source_hash": sourceHash,
 			"target_hash": targetHash,
 		}).
-		RunWith(s.db).
-		Exec()
+		RunWith(exec).
+		ExecContext(ctx)
 
 	return err
 }
 
 // Edge implements graph.Store.Edge.
 func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	return s.edge(context.Background(), s.db, sourceHash, targetHash)
+}
+
+// EdgeCtx behaves like Edge but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) EdgeCtx(ctx context.Context, sourceHash, targetHash K) (graph.Edge[K], error) {
+	return s.edge(ctx, s.db, sourceHash, targetHash)
+}
+
+func (s *Store[K, T]) edge(ctx context.Context, exec sqlExecutor, sourceHash, targetHash K) (graph.Edge[K], error) {
 	edge := graph.Edge[K]{
 		Source: sourceHash,
 		Target: targetHash,
@@ -215,15 +427,15 @@ func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
 
 	var attributesBytes []byte
 
-	err := sq.
+	err := s.sb.
 		Select("weight", "attributes", "data").
 		From(s.config.EdgesTable).
 		Where(sq.Eq{
 			"source_hash": sourceHash,
 			"target_hash": targetHash,
 		}).
-		RunWith(s.db).
-		QueryRow().
+		RunWith(exec).
+		QueryRowContext(ctx).
 		Scan(&edge.Properties.Weight, &attributesBytes, &edge.Properties.Data)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -243,7 +455,16 @@ func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
 
 // ListEdges implements graph.Store.ListEdges.
 func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
-	rows, err := sq.
+	return s.listEdges(context.Background(), s.db)
+}
+
+// ListEdgesCtx behaves like ListEdges but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) ListEdgesCtx(ctx context.Context) ([]graph.Edge[K], error) {
+	return s.listEdges(ctx, s.db)
+}
+
+func (s *Store[K, T]) listEdges(ctx context.Context, exec sqlExecutor) ([]graph.Edge[K], error) {
+	rows, err := s.sb.
 		Select(
 			"source_hash",
 			"target_hash",
@@ -252,71 +473,114 @@ func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
 			"data",
 		).
 		From(s.config.EdgesTable).
-		RunWith(s.db).
-		Query()
+		RunWith(exec).
+		QueryContext(ctx)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to query edges: %w", err)
 	}
 
-	var edges []graph.Edge[K]
+	it := &Iterator[graph.Edge[K]]{
+		rows: rows,
+		scan: scanEdgeRow[K],
+	}
+	defer it.Close()
 
-	for rows.Next() {
-		var (
-			edge            graph.Edge[K]
-			attributesBytes []byte
-		)
-
-		if err := rows.Scan(
-			&edge.Source,
-			&edge.Target,
-			&edge.Properties.Weight,
-			&attributesBytes,
-			&edge.Properties.Data,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
+	var edges []graph.Edge[K]
 
-		if err := json.Unmarshal(attributesBytes, &edge.Properties.Attributes); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal attributes: %w", err)
+	for it.Next() {
+		edge, err := it.Value()
+		if err != nil {
+			return nil, err
 		}
-
 		edges = append(edges, edge)
 	}
 
-	return edges, nil
+	return edges, it.Err()
 }
 
 // EdgeCount implements graph.Store.EdgeCount.
 func (s *Store[K, T]) EdgeCount() (int, error) {
+	return s.edgeCount(context.Background(), s.db)
+}
+
+// EdgeCountCtx behaves like EdgeCount but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) EdgeCountCtx(ctx context.Context) (int, error) {
+	return s.edgeCount(ctx, s.db)
+}
+
+func (s *Store[K, T]) edgeCount(ctx context.Context, exec sqlExecutor) (int, error) {
 	var count int
 
 	// Please note that for some reason count(id) does not return the correct results for sqlite.
-	err := sq.
+	err := s.sb.
 		Select("count(source_hash)").
 		From(s.config.EdgesTable).
-		RunWith(s.db).
-		QueryRow().
+		RunWith(exec).
+		QueryRowContext(ctx).
 		Scan(&count)
 
 	return count, err
 }
 
+// UpdateEdge implements graph.Store.UpdateEdge. If config.MaterializeReverseEdges is enabled, it
+// also writes the mirrored (targetHash, sourceHash) edge in the same transaction, upserting it in
+// case the mirror doesn't exist yet (e.g. the edge was added before MaterializeReverseEdges was
+// turned on and Store.EnsureReverseEdges hasn't been run since).
 func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return s.updateEdgeWithReverse(context.Background(), sourceHash, targetHash, edge)
+}
+
+// UpdateEdgeCtx behaves like UpdateEdge but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) UpdateEdgeCtx(ctx context.Context, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return s.updateEdgeWithReverse(ctx, sourceHash, targetHash, edge)
+}
+
+func (s *Store[K, T]) updateEdgeWithReverse(ctx context.Context, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	if !s.config.MaterializeReverseEdges || sourceHash == targetHash {
+		return s.updateEdge(ctx, s.db, sourceHash, targetHash, edge)
+	}
+
+	return s.WithTx(ctx, func(tx *Tx[K, T]) error {
+		return tx.store.updateEdgeReverseAware(tx.ctx, tx.tx, sourceHash, targetHash, edge)
+	})
+}
+
+// updateEdgeReverseAware updates the (sourceHash, targetHash) edge and, if
+// config.MaterializeReverseEdges is enabled, upserts its mirror, both against exec. The mirror is
+// upserted rather than updated in case it doesn't exist yet (e.g. the edge was added before
+// MaterializeReverseEdges was turned on and Store.EnsureReverseEdges hasn't been run since).
+// Tx.UpdateEdge calls this directly so that grouping writes through WithTx still honors
+// MaterializeReverseEdges the same way Store.UpdateEdge does.
+func (s *Store[K, T]) updateEdgeReverseAware(ctx context.Context, exec sqlExecutor, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	if err := s.updateEdge(ctx, exec, sourceHash, targetHash, edge); err != nil {
+		return err
+	}
+
+	if !s.config.MaterializeReverseEdges || sourceHash == targetHash {
+		return nil
+	}
+
+	reverse := edge
+	reverse.Source, reverse.Target = targetHash, sourceHash
+
+	return s.upsertEdge(ctx, exec, targetHash, sourceHash, reverse)
+}
 
+func (s *Store[K, T]) updateEdge(ctx context.Context, exec sqlExecutor, sourceHash, targetHash K, edge graph.Edge[K]) error {
 	attributesBytes, err := json.Marshal(edge.Properties.Attributes)
 	if err != nil {
 		return err
 	}
 
-	_, err = sq.Update(s.config.EdgesTable).
+	_, err = s.sb.Update(s.config.EdgesTable).
 		Set("weight", edge.Properties.Weight).
 		Set("attributes", attributesBytes).
 		Set("data", edge.Properties.Data).
 		Where("source_hash = ?", sourceHash).
 		Where("target_hash = ?", targetHash).
-		RunWith(s.db).
-		Exec()
+		RunWith(exec).
+		ExecContext(ctx)
 
 	return err
 }
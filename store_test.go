@@ -1,7 +1,9 @@
 package graphsql
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"testing"
@@ -157,3 +159,88 @@ func TestUpdateEdge(t *testing.T) {
 	assert.Equal("xyz", edge.Properties.Attributes["abc"])
 	assert.Equal("happy", edge.Properties.Data)
 }
+
+func TestWithTxCommit(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	err = store.WithTx(context.Background(), func(tx *Tx[int, int]) error {
+		if err := tx.AddVertex(1, 1, graph.VertexProperties{}); err != nil {
+			return err
+		}
+		if err := tx.AddVertex(2, 2, graph.VertexProperties{}); err != nil {
+			return err
+		}
+		return tx.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2})
+	})
+	assert.Nil(err)
+
+	vertexCount, err := store.VertexCount()
+	assert.Nil(err)
+	assert.Equal(2, vertexCount)
+
+	edgeCount, err := store.EdgeCount()
+	assert.Nil(err)
+	assert.Equal(1, edgeCount)
+}
+
+func TestWithTxRollback(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	wantErr := errors.New("something went wrong")
+
+	err = store.WithTx(context.Background(), func(tx *Tx[int, int]) error {
+		if err := tx.AddVertex(1, 1, graph.VertexProperties{}); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	assert.True(errors.Is(err, wantErr))
+
+	vertexCount, err := store.VertexCount()
+	assert.Nil(err)
+	assert.Equal(0, vertexCount)
+}
+
+func TestNeighbors(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+	store.AddVertex(3, 3, graph.VertexProperties{})
+
+	store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2})
+	store.AddEdge(3, 1, graph.Edge[int]{Source: 3, Target: 1})
+
+	hashes, edges, err := store.Neighbors(1)
+	assert.Nil(err)
+	assert.ElementsMatch([]int{2, 3}, hashes)
+	assert.Equal(2, len(edges))
+
+	outEdges, err := store.OutEdges(1)
+	assert.Nil(err)
+	assert.Equal(1, len(outEdges))
+
+	inEdges, err := store.InEdges(1)
+	assert.Nil(err)
+	assert.Equal(1, len(inEdges))
+
+	in, out, err := store.Degree(1)
+	assert.Nil(err)
+	assert.Equal(1, in)
+	assert.Equal(1, out)
+}
@@ -0,0 +1,75 @@
+package graphsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Tx groups multiple vertex and edge writes into a single atomic unit. It is obtained by calling
+// Store.WithTx and provides AddVertex, AddEdge, RemoveEdge and UpdateEdge, which behave like the
+// Store methods of the same name, except that every statement runs against the same *sql.Tx and
+// is only persisted once the transaction is committed. Tx has no RemoveVertex, UpsertVertex or
+// UpsertEdge.
+type Tx[K comparable, T any] struct {
+	store *Store[K, T]
+	tx    *sql.Tx
+	ctx   context.Context
+}
+
+// WithTx runs fn against a new transaction, committing it if fn returns nil and rolling it back
+// otherwise. Use the Tx passed into fn to perform the writes that should be grouped together -
+// for example to make a bulk load of vertices and edges atomic.
+func (s *Store[K, T]) WithTx(ctx context.Context, fn func(tx *Tx[K, T]) error) error {
+	sqlTx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	tx := &Tx[K, T]{
+		store: s,
+		tx:    sqlTx,
+		ctx:   ctx,
+	}
+
+	if err := fn(tx); err != nil {
+		if rbErr := sqlTx.Rollback(); rbErr != nil {
+			return fmt.Errorf("failed to roll back transaction after error %v: %w", err, rbErr)
+		}
+		return err
+	}
+
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// AddVertex behaves like Store.AddVertex, but runs inside the transaction.
+func (tx *Tx[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	return tx.store.addVertex(tx.ctx, tx.tx, hash, value, properties)
+}
+
+// AddEdge behaves like Store.AddEdge, but runs inside the transaction. Like Store.AddEdge, it
+// also writes the mirrored (targetHash, sourceHash) edge if config.MaterializeReverseEdges is
+// enabled.
+func (tx *Tx[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return tx.store.addEdgeReverseAware(tx.ctx, tx.tx, sourceHash, targetHash, edge)
+}
+
+// RemoveEdge behaves like Store.RemoveEdge, but runs inside the transaction. Like
+// Store.RemoveEdge, it also removes the mirrored (targetHash, sourceHash) edge if
+// config.MaterializeReverseEdges is enabled.
+func (tx *Tx[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	return tx.store.removeEdgeReverseAware(tx.ctx, tx.tx, sourceHash, targetHash)
+}
+
+// UpdateEdge behaves like Store.UpdateEdge, but runs inside the transaction. Like
+// Store.UpdateEdge, it also upserts the mirrored (targetHash, sourceHash) edge if
+// config.MaterializeReverseEdges is enabled.
+func (tx *Tx[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return tx.store.updateEdgeReverseAware(tx.ctx, tx.tx, sourceHash, targetHash, edge)
+}
@@ -0,0 +1,83 @@
+package graphsql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dominikbraun/graph"
+)
+
+// UpsertVertex inserts the given vertex, or updates its value, weight and attributes if a vertex
+// with the same hash already exists. Unlike AddVertex, it never returns
+// graph.ErrVertexAlreadyExists.
+func (s *Store[K, T]) UpsertVertex(hash K, value T, properties graph.VertexProperties) error {
+	return s.upsertVertex(context.Background(), s.db, hash, value, properties)
+}
+
+// UpsertVertexCtx behaves like UpsertVertex but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) UpsertVertexCtx(ctx context.Context, hash K, value T, properties graph.VertexProperties) error {
+	return s.upsertVertex(ctx, s.db, hash, value, properties)
+}
+
+func (s *Store[K, T]) upsertVertex(ctx context.Context, exec sqlExecutor, hash K, value T, properties graph.VertexProperties) error {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	attributeBytes, err := json.Marshal(properties.Attributes)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.sb.
+		Insert(s.config.VerticesTable).
+		Columns("hash", "value", "weight", "attributes").
+		Values(hash, valueBytes, properties.Weight, attributeBytes).
+		Suffix(s.config.Dialect.OnConflict([]string{"hash"}, []string{"value", "weight", "attributes"})).
+		RunWith(exec).
+		ExecContext(ctx)
+
+	return err
+}
+
+// UpsertEdge inserts the given edge, or updates its weight, attributes and data if an edge
+// between the same source and target already exists. Unlike AddEdge, it never returns
+// graph.ErrEdgeAlreadyExists.
+func (s *Store[K, T]) UpsertEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return s.upsertEdge(context.Background(), s.db, sourceHash, targetHash, edge)
+}
+
+// UpsertEdgeCtx behaves like UpsertEdge but threads ctx through the underlying SQL execution.
+func (s *Store[K, T]) UpsertEdgeCtx(ctx context.Context, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return s.upsertEdge(ctx, s.db, sourceHash, targetHash, edge)
+}
+
+func (s *Store[K, T]) upsertEdge(ctx context.Context, exec sqlExecutor, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	attributesBytes, err := json.Marshal(edge.Properties.Attributes)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.sb.
+		Insert(s.config.EdgesTable).
+		Columns(
+			"source_hash",
+			"target_hash",
+			"weight",
+			"attributes",
+			"data",
+		).
+		Values(
+			sourceHash,
+			targetHash,
+			edge.Properties.Weight,
+			attributesBytes,
+			edge.Properties.Data,
+		).
+		Suffix(s.config.Dialect.OnConflict([]string{"source_hash", "target_hash"}, []string{"weight", "attributes", "data"})).
+		RunWith(exec).
+		ExecContext(ctx)
+
+	return err
+}
@@ -0,0 +1,96 @@
+package graphsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestAddVertexAlreadyExists(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	assert.Nil(store.AddVertex(1, 1, graph.VertexProperties{}))
+
+	err = store.AddVertex(1, 2, graph.VertexProperties{})
+	assert.Equal(graph.ErrVertexAlreadyExists, err)
+}
+
+func TestAddEdgeAlreadyExists(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+
+	assert.Nil(store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2}))
+
+	err = store.AddEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2})
+	assert.Equal(graph.ErrEdgeAlreadyExists, err)
+}
+
+func TestVertexNotFound(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	_, _, err = store.Vertex(1)
+	assert.Equal(graph.ErrVertexNotFound, err)
+}
+
+func TestUpsertVertex(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	assert.Nil(store.UpsertVertex(1, 1, graph.VertexProperties{Weight: 1}))
+	assert.Nil(store.UpsertVertex(1, 2, graph.VertexProperties{Weight: 2}))
+
+	value, properties, err := store.Vertex(1)
+	assert.Nil(err)
+	assert.Equal(2, value)
+	assert.Equal(2, properties.Weight)
+
+	vertexCount, err := store.VertexCount()
+	assert.Nil(err)
+	assert.Equal(1, vertexCount)
+}
+
+func TestUpsertEdge(t *testing.T) {
+
+	assert := assert.New(t)
+
+	store, err := createStore[int, int]()
+	assert.Nil(err)
+	assert.NotNil(store)
+
+	store.AddVertex(1, 1, graph.VertexProperties{})
+	store.AddVertex(2, 2, graph.VertexProperties{})
+
+	assert.Nil(store.UpsertEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2, Properties: graph.EdgeProperties{Weight: 1}}))
+	assert.Nil(store.UpsertEdge(1, 2, graph.Edge[int]{Source: 1, Target: 2, Properties: graph.EdgeProperties{Weight: 5}}))
+
+	edge, err := store.Edge(1, 2)
+	assert.Nil(err)
+	assert.Equal(5, edge.Properties.Weight)
+
+	edgeCount, err := store.EdgeCount()
+	assert.Nil(err)
+	assert.Equal(1, edgeCount)
+}